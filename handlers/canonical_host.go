@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// CanonicalHost returns a func(http.Handler) http.Handler - the curried
+// form used by chaining-style middleware libraries - that redirects any
+// request whose Host does not match domain to the same path and query on
+// domain, using code (typically http.StatusMovedPermanently or
+// http.StatusFound) as the redirect status. domain is parsed with
+// url.Parse immediately, so a malformed value panics at construction time
+// instead of the handler silently never matching.
+func CanonicalHost(domain string, code int) func(http.Handler) http.Handler {
+	host := mustCanonicalHost(domain)
+	return func(h http.Handler) http.Handler {
+		return canonicalHostHandler(h, host, code)
+	}
+}
+
+// CanonicalHostHandler is the non-curried form of CanonicalHost, matching
+// the h-first style of LogHandler and the other handlers in this package.
+func CanonicalHostHandler(h http.Handler, domain string, code int) http.Handler {
+	return canonicalHostHandler(h, mustCanonicalHost(domain), code)
+}
+
+func canonicalHostHandler(h http.Handler, host string, code int) http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Host == "" || sameHost(r.Host, host) {
+				h.ServeHTTP(w, r)
+				return
+			}
+
+			scheme := r.URL.Scheme
+			if scheme == "" {
+				if r.TLS != nil {
+					scheme = "https"
+				} else {
+					scheme = "http"
+				}
+			}
+			u := *r.URL
+			u.Scheme = scheme
+			u.Host = host
+			http.Redirect(w, r, u.String(), code)
+		})
+}
+
+// mustCanonicalHost validates domain and returns its host[:port] form,
+// panicking if it doesn't parse as a valid host - the handlers.Canonical*
+// equivalent of regexp.MustCompile.
+func mustCanonicalHost(domain string) string {
+	raw := domain
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		panic(fmt.Sprintf("handlers: invalid CanonicalHost domain %q", domain))
+	}
+	return u.Host
+}
+
+// sameHost compares two Host header values case-insensitively, ignoring a
+// default (80 or 443) port on either side.
+func sameHost(a, b string) bool {
+	return strings.EqualFold(stripDefaultPort(a), stripDefaultPort(b))
+}
+
+func stripDefaultPort(hostport string) string {
+	host, port, err := net.SplitHostPort(hostport)
+	if err != nil {
+		return hostport
+	}
+	if port == "80" || port == "443" {
+		return host
+	}
+	return hostport
+}
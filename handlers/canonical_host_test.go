@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCanonicalHostRedirectsMismatchedHost(t *testing.T) {
+	h := CanonicalHostHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("inner handler should not be called")
+	}), "example.com", http.StatusMovedPermanently)
+
+	r := httptest.NewRequest("GET", "http://www.example.com/foo?bar=1", nil)
+	r.Host = "www.example.com"
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if w.Code != http.StatusMovedPermanently {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusMovedPermanently)
+	}
+	if got, want := w.Header().Get("Location"), "http://example.com/foo?bar=1"; got != want {
+		t.Errorf("Location = %q, want %q", got, want)
+	}
+}
+
+func TestCanonicalHostPassesThroughMatchingHost(t *testing.T) {
+	called := false
+	h := CanonicalHostHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}), "example.com", http.StatusMovedPermanently)
+
+	r := httptest.NewRequest("GET", "http://example.com/foo", nil)
+	r.Host = "example.com"
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if !called {
+		t.Errorf("inner handler was not called for a matching Host")
+	}
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+}
+
+func TestCanonicalHostIgnoresDefaultPort(t *testing.T) {
+	called := false
+	h := CanonicalHostHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}), "example.com", http.StatusMovedPermanently)
+
+	r := httptest.NewRequest("GET", "http://example.com/foo", nil)
+	r.Host = "example.com:80"
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if !called {
+		t.Errorf("inner handler was not called for a Host differing only by the default port")
+	}
+}
+
+func TestCanonicalHostSkipsEmptyHost(t *testing.T) {
+	called := false
+	h := CanonicalHostHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}), "example.com", http.StatusMovedPermanently)
+
+	r := httptest.NewRequest("GET", "/foo", nil)
+	r.Host = ""
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if !called {
+		t.Errorf("inner handler was not called for an empty Host")
+	}
+}
+
+func TestCanonicalHostPreservesHTTPSScheme(t *testing.T) {
+	h := CanonicalHostHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("inner handler should not be called")
+	}), "example.com", http.StatusFound)
+
+	r := httptest.NewRequest("GET", "https://www.example.com/foo", nil)
+	r.Host = "www.example.com"
+	r.URL.Scheme = "https"
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if got, want := w.Header().Get("Location"), "https://example.com/foo"; got != want {
+		t.Errorf("Location = %q, want %q", got, want)
+	}
+}
+
+func TestCanonicalHostPanicsOnInvalidDomain(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected a panic for an invalid domain")
+		}
+	}()
+	CanonicalHost("http://[::1", http.StatusMovedPermanently)
+}
+
+func TestCanonicalHostCurriedForm(t *testing.T) {
+	called := false
+	mw := CanonicalHost("example.com", http.StatusMovedPermanently)
+	h := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+
+	r := httptest.NewRequest("GET", "http://example.com/", nil)
+	r.Host = "example.com"
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if !called {
+		t.Errorf("inner handler was not called through the curried form")
+	}
+}
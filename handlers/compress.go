@@ -0,0 +1,297 @@
+package handlers
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/ghost/handlers/rwwrap"
+)
+
+// defaultCompressibleTypes is CompressOptions' default ContentTypes. Prefixes
+// ending in "/" match any subtype.
+var defaultCompressibleTypes = []string{
+	"text/",
+	"application/json",
+	"application/javascript",
+	"image/svg+xml",
+}
+
+// CompressOptions configures CompressHandler.
+type CompressOptions struct {
+	// Level is the compress/flate compression level (flate.NoCompression
+	// .. flate.BestCompression, or flate.DefaultCompression).
+	Level int
+
+	// MinSize is the number of response bytes buffered before deciding
+	// whether to compress, so tiny responses aren't inflated by
+	// compression overhead. Defaults to 1024.
+	MinSize int
+
+	// ContentTypes allowlists the response Content-Types eligible for
+	// compression; an entry ending in "/" matches any subtype. Defaults to
+	// text/*, application/json, application/javascript and image/svg+xml.
+	ContentTypes []string
+}
+
+// NewCompressOptions returns a *CompressOptions with the package defaults:
+// gzip.DefaultCompression, a 1024 byte MinSize and the default
+// ContentTypes allowlist.
+func NewCompressOptions() *CompressOptions {
+	return &CompressOptions{
+		Level:        gzip.DefaultCompression,
+		MinSize:      1024,
+		ContentTypes: defaultCompressibleTypes,
+	}
+}
+
+func (o *CompressOptions) minSize() int {
+	if o.MinSize > 0 {
+		return o.MinSize
+	}
+	return 1024
+}
+
+// level returns o.Level if it is a valid flate/gzip compression level, and
+// gzip.DefaultCompression otherwise, so an invalid Level (e.g. a typo) falls
+// back to a sane default instead of producing a nil compressor.
+func (o *CompressOptions) level() int {
+	if o.Level >= gzip.HuffmanOnly && o.Level <= gzip.BestCompression {
+		return o.Level
+	}
+	return gzip.DefaultCompression
+}
+
+func (o *CompressOptions) typeAllowed(contentType string) bool {
+	types := o.ContentTypes
+	if len(types) == 0 {
+		types = defaultCompressibleTypes
+	}
+	if i := strings.IndexByte(contentType, ';'); i != -1 {
+		contentType = contentType[:i]
+	}
+	contentType = strings.TrimSpace(contentType)
+	for _, t := range types {
+		if strings.HasSuffix(t, "/") {
+			if strings.HasPrefix(contentType, t) {
+				return true
+			}
+		} else if strings.EqualFold(contentType, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// CompressHandler compresses h's response body with gzip or deflate,
+// negotiated from the request's Accept-Encoding header, while preserving
+// whichever optional interfaces (http.Hijacker, http.Flusher, ...) the
+// underlying writer implements (see package rwwrap). It buffers the first
+// opts.MinSize response bytes to decide whether compressing is worthwhile,
+// skips HEAD requests, 1xx/204/304 responses and responses that already set
+// their own Content-Encoding, and always appends Vary: Accept-Encoding. A
+// nil opts is equivalent to NewCompressOptions().
+func CompressHandler(h http.Handler, opts *CompressOptions) http.Handler {
+	if opts == nil {
+		opts = NewCompressOptions()
+	}
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			addVary(w, "Accept-Encoding")
+
+			if r.Method == "HEAD" {
+				h.ServeHTTP(w, r)
+				return
+			}
+			enc := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+			if enc == "" {
+				h.ServeHTTP(w, r)
+				return
+			}
+
+			cs := &compressState{w: w, enc: enc, opts: opts}
+			cw := rwwrap.Wrap(w, rwwrap.Hooks{
+				WriteHeader: func(next rwwrap.WriteHeaderFunc) rwwrap.WriteHeaderFunc {
+					cs.realWriteHeader = next
+					return func(code int) { cs.statusCode = code }
+				},
+				Write: func(next rwwrap.WriteFunc) rwwrap.WriteFunc {
+					cs.realWrite = next
+					return cs.write
+				},
+				ReadFrom: func(next rwwrap.ReadFromFunc) rwwrap.ReadFromFunc {
+					return func(src io.Reader) (int64, error) {
+						return io.Copy(writerFunc(cs.write), src)
+					}
+				},
+				Flush: func(next rwwrap.FlushFunc) rwwrap.FlushFunc {
+					return func() { cs.flush(next) }
+				},
+			})
+			defer cs.finish()
+			h.ServeHTTP(cw, r)
+		})
+}
+
+// compressState tracks the decision of whether to compress a single
+// response: it buffers writes until opts.MinSize is reached (or the
+// handler explicitly flushes or returns), then commits to compressing or
+// passing the bytes through untouched.
+type compressState struct {
+	w    http.ResponseWriter
+	enc  string
+	opts *CompressOptions
+
+	realWriteHeader rwwrap.WriteHeaderFunc
+	realWrite       rwwrap.WriteFunc
+
+	buf        bytes.Buffer
+	statusCode int
+	decided    bool
+	compress   bool
+	compressor io.WriteCloser
+}
+
+type writerFunc rwwrap.WriteFunc
+
+func (f writerFunc) Write(p []byte) (int, error) { return f(p) }
+
+func (s *compressState) write(data []byte) (int, error) {
+	if s.decided {
+		if s.compress {
+			return s.compressor.Write(data)
+		}
+		return s.realWrite(data)
+	}
+	s.buf.Write(data)
+	if s.buf.Len() >= s.opts.minSize() {
+		s.commit()
+	}
+	return len(data), nil
+}
+
+func (s *compressState) flush(realFlush rwwrap.FlushFunc) {
+	s.commit()
+	if s.compress {
+		if fl, ok := s.compressor.(interface{ Flush() error }); ok {
+			fl.Flush()
+		}
+	}
+	if realFlush != nil {
+		realFlush()
+	}
+}
+
+func (s *compressState) finish() {
+	s.commit()
+	if s.compress {
+		s.compressor.Close()
+	}
+}
+
+// commit decides, the first time it is called, whether the response will
+// be compressed, then emits the (possibly adjusted) response header
+// followed by whatever was buffered so far. It is idempotent so it can be
+// called from write, flush and finish without double-emitting.
+func (s *compressState) commit() {
+	if s.decided {
+		return
+	}
+	s.decided = true
+
+	code := s.statusCode
+	if code == 0 {
+		code = http.StatusOK
+	}
+	contentType := s.w.Header().Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(s.buf.Bytes())
+	}
+	alreadyEncoded := s.w.Header().Get("Content-Encoding") != ""
+	if !alreadyEncoded && isCompressibleStatus(code) && s.opts.typeAllowed(contentType) && s.buf.Len() >= s.opts.minSize() {
+		level := s.opts.level()
+		var err error
+		if s.enc == "gzip" {
+			s.compressor, err = gzip.NewWriterLevel(writerFunc(s.realWrite), level)
+		} else {
+			s.compressor, err = flate.NewWriter(writerFunc(s.realWrite), level)
+		}
+		if err == nil {
+			s.compress = true
+			s.w.Header().Del("Content-Length")
+			s.w.Header().Set("Content-Encoding", s.enc)
+		}
+	}
+
+	s.realWriteHeader(code)
+	if s.buf.Len() == 0 {
+		return
+	}
+	if s.compress {
+		s.compressor.Write(s.buf.Bytes())
+	} else {
+		s.realWrite(s.buf.Bytes())
+	}
+	s.buf.Reset()
+}
+
+func isCompressibleStatus(code int) bool {
+	return code/100 != 1 && code != http.StatusNoContent && code != http.StatusNotModified
+}
+
+// negotiateEncoding picks gzip or deflate out of an Accept-Encoding header,
+// honoring q-values and the "*" wildcard, and returns "" if neither is
+// acceptable (including when the header is absent, to be conservative).
+func negotiateEncoding(acceptEncoding string) string {
+	if acceptEncoding == "" {
+		return ""
+	}
+	qs := make(map[string]float64)
+	wildcard := -1.0
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, q := parseEncodingQ(part)
+		if name == "" {
+			continue
+		}
+		if name == "*" {
+			wildcard = q
+			continue
+		}
+		qs[name] = q
+	}
+
+	best, bestQ := "", 0.0
+	for _, enc := range []string{"gzip", "deflate"} {
+		q, ok := qs[enc]
+		if !ok {
+			if wildcard < 0 {
+				continue
+			}
+			q = wildcard
+		}
+		if q > 0 && q > bestQ {
+			best, bestQ = enc, q
+		}
+	}
+	return best
+}
+
+func parseEncodingQ(part string) (name string, q float64) {
+	part = strings.TrimSpace(part)
+	if part == "" {
+		return "", 0
+	}
+	name, params, _ := strings.Cut(part, ";")
+	name = strings.ToLower(strings.TrimSpace(name))
+	q = 1.0
+	if k, v, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.TrimSpace(k) == "q" {
+		if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+			q = f
+		}
+	}
+	return name, q
+}
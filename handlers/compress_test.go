@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCompressHandlerCompressesAllowedType(t *testing.T) {
+	body := strings.Repeat("hello world ", 200) // well over the default MinSize
+	h := CompressHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(body))
+	}), nil)
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if got := w.Header().Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("Content-Encoding = %q, want gzip", got)
+	}
+	if got := w.Header().Get("Content-Length"); got != "" {
+		t.Errorf("Content-Length = %q, want stripped", got)
+	}
+	gz, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatalf("gzip.NewReader: %v", err)
+	}
+	got, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatalf("reading gzip body: %v", err)
+	}
+	if string(got) != body {
+		t.Errorf("decompressed body mismatch")
+	}
+}
+
+func TestCompressHandlerSkipsTinyResponses(t *testing.T) {
+	opts := NewCompressOptions()
+	opts.MinSize = 1024
+	h := CompressHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("tiny"))
+	}), opts)
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if got := w.Header().Get("Content-Encoding"); got != "" {
+		t.Errorf("Content-Encoding = %q, want empty for a response under MinSize", got)
+	}
+	if w.Body.String() != "tiny" {
+		t.Errorf("body = %q, want %q", w.Body.String(), "tiny")
+	}
+}
+
+func TestCompressHandlerSkipsDisallowedType(t *testing.T) {
+	body := bytes.Repeat([]byte{0, 1, 2, 3}, 500)
+	h := CompressHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write(body)
+	}), nil)
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if got := w.Header().Get("Content-Encoding"); got != "" {
+		t.Errorf("Content-Encoding = %q, want empty for a disallowed content type", got)
+	}
+	if !bytes.Equal(w.Body.Bytes(), body) {
+		t.Errorf("body mismatch for a pass-through response")
+	}
+}
+
+func TestCompressHandlerSkipsAlreadyEncodedResponse(t *testing.T) {
+	body := strings.Repeat("hello world ", 200) // well over the default MinSize
+	h := CompressHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("Content-Encoding", "br")
+		w.Write([]byte(body))
+	}), nil)
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if got := w.Header().Get("Content-Encoding"); got != "br" {
+		t.Fatalf("Content-Encoding = %q, want br (untouched, not double-encoded as gzip)", got)
+	}
+	if w.Body.String() != body {
+		t.Errorf("body was compressed on top of the handler's own encoding")
+	}
+}
+
+func TestCompressHandlerVaryHeader(t *testing.T) {
+	h := CompressHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("x"))
+	}), nil)
+
+	r := httptest.NewRequest("GET", "/", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if got := w.Header().Get("Vary"); got != "Accept-Encoding" {
+		t.Errorf("Vary = %q, want Accept-Encoding", got)
+	}
+}
+
+func TestNegotiateEncodingPrefersHigherQ(t *testing.T) {
+	if got := negotiateEncoding("deflate;q=0.5, gzip;q=0.9"); got != "gzip" {
+		t.Errorf("negotiateEncoding = %q, want gzip", got)
+	}
+	if got := negotiateEncoding("gzip;q=0"); got != "" {
+		t.Errorf("negotiateEncoding = %q, want empty when gzip is explicitly rejected", got)
+	}
+	if got := negotiateEncoding(""); got != "" {
+		t.Errorf("negotiateEncoding(%q) = %q, want empty", "", got)
+	}
+}
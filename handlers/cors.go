@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// CORSOptions configures CORSHandler.
+type CORSOptions struct {
+	// AllowedOrigins lists the origins allowed to make cross-origin
+	// requests. A single entry of "*" allows any origin. If
+	// AllowCredentials is set, "*" is never sent back as-is - the actual
+	// request origin is echoed instead, since browsers reject a wildcard
+	// Access-Control-Allow-Origin on credentialed requests.
+	AllowedOrigins []string
+
+	// AllowOriginFunc, if set, is consulted for origins not matched by
+	// AllowedOrigins, letting callers allow origins dynamically (e.g. a
+	// suffix or regexp match) instead of listing them all.
+	AllowOriginFunc func(origin string) bool
+
+	// AllowedMethods lists the methods a preflight request may report via
+	// Access-Control-Request-Method. Defaults to GET, HEAD and POST.
+	AllowedMethods []string
+
+	// AllowedHeaders lists the request headers a preflight request may
+	// report via Access-Control-Request-Headers. If empty, the requested
+	// headers are echoed back unconditionally.
+	AllowedHeaders []string
+
+	// ExposedHeaders lists the response headers, beyond the CORS-safelisted
+	// ones, that scripts running on an allowed origin may read.
+	ExposedHeaders []string
+
+	// AllowCredentials sets Access-Control-Allow-Credentials: true and
+	// disables the "*" wildcard shortcut described on AllowedOrigins.
+	AllowCredentials bool
+
+	// MaxAge is the number of seconds a preflight response may be cached
+	// for. Zero omits the Access-Control-Max-Age header.
+	MaxAge int
+
+	// AllowPrivateNetwork answers the Private Network Access preflight
+	// (Access-Control-Request-Private-Network) by granting access to a
+	// more-private address space, per the W3C draft.
+	AllowPrivateNetwork bool
+}
+
+// NewCORSOptions returns a *CORSOptions with the package's default allowed
+// methods set.
+func NewCORSOptions() *CORSOptions {
+	return &CORSOptions{
+		AllowedMethods: []string{"GET", "HEAD", "POST"},
+	}
+}
+
+// CORSHandler adds Cross-Origin Resource Sharing headers to h's responses,
+// and answers CORS preflight (OPTIONS) requests without calling h. A nil
+// opts is equivalent to NewCORSOptions(), i.e. no origins allowed.
+func CORSHandler(h http.Handler, opts *CORSOptions) http.Handler {
+	if opts == nil {
+		opts = NewCORSOptions()
+	}
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			addVary(w, "Origin")
+
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				// Not a CORS request.
+				h.ServeHTTP(w, r)
+				return
+			}
+
+			if r.Method == "OPTIONS" && r.Header.Get("Access-Control-Request-Method") != "" {
+				serveCORSPreflight(w, r, opts, origin)
+				return
+			}
+
+			if allowed, wildcard := isOriginAllowed(opts, origin); allowed {
+				setAllowOrigin(w, opts, origin, wildcard)
+				if opts.AllowCredentials {
+					w.Header().Set("Access-Control-Allow-Credentials", "true")
+				}
+				if len(opts.ExposedHeaders) > 0 {
+					w.Header().Set("Access-Control-Expose-Headers", strings.Join(opts.ExposedHeaders, ", "))
+				}
+			}
+			h.ServeHTTP(w, r)
+		})
+}
+
+// serveCORSPreflight answers an OPTIONS preflight request with a 204 and
+// the negotiated Access-Control-Allow-* headers, or with no CORS headers at
+// all if origin is not allowed - the browser is left to enforce that.
+func serveCORSPreflight(w http.ResponseWriter, r *http.Request, opts *CORSOptions, origin string) {
+	addVary(w, "Access-Control-Request-Method")
+	addVary(w, "Access-Control-Request-Headers")
+
+	allowed, wildcard := isOriginAllowed(opts, origin)
+	if allowed {
+		setAllowOrigin(w, opts, origin, wildcard)
+		if opts.AllowCredentials {
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
+		w.Header().Set("Access-Control-Allow-Methods", strings.Join(opts.AllowedMethods, ", "))
+
+		reqHeaders := r.Header.Get("Access-Control-Request-Headers")
+		if len(opts.AllowedHeaders) > 0 {
+			w.Header().Set("Access-Control-Allow-Headers", strings.Join(opts.AllowedHeaders, ", "))
+		} else if reqHeaders != "" {
+			w.Header().Set("Access-Control-Allow-Headers", reqHeaders)
+		}
+
+		if opts.MaxAge > 0 {
+			w.Header().Set("Access-Control-Max-Age", strconv.Itoa(opts.MaxAge))
+		}
+		if opts.AllowPrivateNetwork && r.Header.Get("Access-Control-Request-Private-Network") == "true" {
+			w.Header().Set("Access-Control-Allow-Private-Network", "true")
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// isOriginAllowed reports whether origin may access the response, and
+// whether it matched through the "*" wildcard entry in AllowedOrigins
+// (as opposed to an exact match or AllowOriginFunc).
+func isOriginAllowed(opts *CORSOptions, origin string) (allowed, wildcard bool) {
+	for _, o := range opts.AllowedOrigins {
+		if o == "*" {
+			return true, true
+		}
+		if strings.EqualFold(o, origin) {
+			return true, false
+		}
+	}
+	if opts.AllowOriginFunc != nil && opts.AllowOriginFunc(origin) {
+		return true, false
+	}
+	return false, false
+}
+
+// setAllowOrigin writes the Access-Control-Allow-Origin header, echoing
+// origin instead of "*" whenever credentials are allowed.
+func setAllowOrigin(w http.ResponseWriter, opts *CORSOptions, origin string, wildcard bool) {
+	if wildcard && !opts.AllowCredentials {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		return
+	}
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+}
+
+// addVary appends v to the Vary header, unless it is already present.
+func addVary(w http.ResponseWriter, v string) {
+	for _, existing := range w.Header().Values("Vary") {
+		for _, part := range strings.Split(existing, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), v) {
+				return
+			}
+		}
+	}
+	w.Header().Add("Vary", v)
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCORSHandlerCredentialedRequestDoesNotUseWildcard(t *testing.T) {
+	opts := NewCORSOptions()
+	opts.AllowedOrigins = []string{"*"}
+	opts.AllowCredentials = true
+
+	h := CORSHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), opts)
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Origin", "http://example.com")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "http://example.com" {
+		t.Errorf("Access-Control-Allow-Origin = %q, want echoed origin, not wildcard", got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "true" {
+		t.Errorf("Access-Control-Allow-Credentials = %q, want true", got)
+	}
+}
+
+func TestCORSHandlerPreflightCaching(t *testing.T) {
+	opts := NewCORSOptions()
+	opts.AllowedOrigins = []string{"http://example.com"}
+	opts.AllowedMethods = []string{"GET", "PUT"}
+	opts.MaxAge = 600
+
+	h := CORSHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("inner handler should not run for a preflight request")
+	}), opts)
+
+	r := httptest.NewRequest("OPTIONS", "/", nil)
+	r.Header.Set("Origin", "http://example.com")
+	r.Header.Set("Access-Control-Request-Method", "PUT")
+	r.Header.Set("Access-Control-Request-Headers", "X-Custom")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want 204", w.Code)
+	}
+	if got := w.Header().Get("Access-Control-Max-Age"); got != "600" {
+		t.Errorf("Access-Control-Max-Age = %q, want 600", got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Methods"); got != "GET, PUT" {
+		t.Errorf("Access-Control-Allow-Methods = %q, want %q", got, "GET, PUT")
+	}
+	if got := w.Header().Get("Access-Control-Allow-Headers"); got != "X-Custom" {
+		t.Errorf("Access-Control-Allow-Headers = %q, want %q", got, "X-Custom")
+	}
+}
+
+func TestCORSHandlerDisallowedOriginOmitsACAO(t *testing.T) {
+	opts := NewCORSOptions()
+	opts.AllowedOrigins = []string{"http://allowed.example.com"}
+
+	h := CORSHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), opts)
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Origin", "http://evil.example.com")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (request passes through, browser enforces CORS)", w.Code)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("Access-Control-Allow-Origin = %q, want empty for a disallowed origin", got)
+	}
+}
+
+func TestCORSHandlerMultipleVaryValues(t *testing.T) {
+	opts := NewCORSOptions()
+	opts.AllowedOrigins = []string{"http://example.com"}
+
+	h := CORSHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("inner handler should not run for a preflight request")
+	}), opts)
+
+	r := httptest.NewRequest("OPTIONS", "/", nil)
+	r.Header.Set("Origin", "http://example.com")
+	r.Header.Set("Access-Control-Request-Method", "GET")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	vary := strings.Join(w.Header().Values("Vary"), ",")
+	for _, want := range []string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"} {
+		if !strings.Contains(vary, want) {
+			t.Errorf("Vary = %q, want it to contain %q", vary, want)
+		}
+	}
+}
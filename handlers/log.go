@@ -4,18 +4,30 @@ package handlers
 // https://github.com/senchalabs/connect
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"regexp"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/PuerkitoBio/ghost/handlers/rwwrap"
 )
 
 const (
 	// Predefined logging formats that can be passed as format string.
-	Ldefault = "_default_"
-	Lshort   = "_short_"
-	Ltiny    = "_tiny_"
+	Ldefault  = "_default_"
+	Lshort    = "_short_"
+	Ltiny     = "_tiny_"
+	Lcommon   = "_common_"
+	Lcombined = "_combined_"
+
+	// clfDateFormat is the date layout Apache's Common and Combined Log
+	// Formats use; time.RFC3339 (the "date" token's format) is not CLF-compliant.
+	clfDateFormat = "02/Jan/2006:15:04:05 -0700"
 )
 
 var (
@@ -39,32 +51,66 @@ var (
 			`%s %s %d %s - %.3f s`,
 			[]string{"method", "url", "status", "res[Content-Length]", "response-time"},
 		},
+		// Lcommon matches the Apache Common Log Format.
+		Lcommon: {
+			`%s - - [%s] "%s %s HTTP/%s" %d %d`,
+			[]string{"remote-addr", "clf-date", "method", "url", "http-version", "status", "bytes-sent"},
+		},
+		// Lcombined matches the Apache Combined Log Format (Common plus
+		// referrer and user-agent).
+		Lcombined: {
+			`%s - - [%s] "%s %s HTTP/%s" %d %d "%s" "%s"`,
+			[]string{"remote-addr", "clf-date", "method", "url", "http-version", "status", "bytes-sent", "referrer", "user-agent"},
+		},
 	}
 )
 
-// Augmented ResponseWriter implementation that captures the status code for the logger.
-type statusResponseWriter struct {
-	http.ResponseWriter
-	code int
-}
-
-// Intercept the WriteHeader call to save the status code.
-func (this *statusResponseWriter) WriteHeader(code int) {
-	this.code = code
-	this.ResponseWriter.WriteHeader(code)
+// statusCapture holds the status code and byte count that the rwwrap hooks
+// installed by wrapStatusWriter record for a single request. It is attached
+// to the wrapped writer via rwwrap.Hooks.Tag so getStatusCapture can find it
+// again, even nested behind other rwwrap-based wrappers.
+type statusCapture struct {
+	code  int
+	bytes int64
 }
 
-// Intercept the Write call to save the default status code.
-func (this *statusResponseWriter) Write(data []byte) (int, error) {
-	if this.code == 0 {
-		this.code = http.StatusOK
-	}
-	return this.ResponseWriter.Write(data)
-}
-
-// Implement the WrapWriter interface.
-func (this *statusResponseWriter) WrappedWriter() http.ResponseWriter {
-	return this.ResponseWriter
+// wrapStatusWriter wraps w with an httpsnoop-style writer (see package
+// rwwrap) that records the response status code and byte count, while
+// still implementing whichever of http.Hijacker, http.Flusher, http.Pusher,
+// http.CloseNotifier and io.ReaderFrom w itself implements. This is what
+// used to be the statusResponseWriter type.
+func wrapStatusWriter(w http.ResponseWriter) (http.ResponseWriter, *statusCapture) {
+	sc := &statusCapture{}
+	stw := rwwrap.Wrap(w, rwwrap.Hooks{
+		WriteHeader: func(next rwwrap.WriteHeaderFunc) rwwrap.WriteHeaderFunc {
+			return func(code int) {
+				sc.code = code
+				next(code)
+			}
+		},
+		Write: func(next rwwrap.WriteFunc) rwwrap.WriteFunc {
+			return func(data []byte) (int, error) {
+				if sc.code == 0 {
+					sc.code = http.StatusOK
+				}
+				n, err := next(data)
+				sc.bytes += int64(n)
+				return n, err
+			}
+		},
+		ReadFrom: func(next rwwrap.ReadFromFunc) rwwrap.ReadFromFunc {
+			return func(src io.Reader) (int64, error) {
+				if sc.code == 0 {
+					sc.code = http.StatusOK
+				}
+				n, err := next(src)
+				sc.bytes += n
+				return n, err
+			}
+		},
+		Tag: sc,
+	})
+	return stw, sc
 }
 
 // LogHandler options
@@ -75,6 +121,11 @@ type LogOptions struct {
 	CustomTokens map[string]func(http.ResponseWriter, *http.Request) string
 	Immediate    bool
 	DateFormat   string
+
+	// Structured, if true, makes logRequest emit one JSON object per
+	// request - keyed by token name - instead of formatting Format as a
+	// printf string.
+	Structured bool
 }
 
 // Create a new LogOptions struct. The DateFormat defaults to time.RFC3339.
@@ -92,7 +143,7 @@ func NewLogOptions(l *log.Logger, ft string, tok ...string) *LogOptions {
 func LogHandler(h http.Handler, opts *LogOptions) http.Handler {
 	return http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
-			if _, ok := getStatusWriter(w); ok {
+			if _, ok := getStatusCapture(w); ok {
 				// Self-awareness, logging handler already set up
 				h.ServeHTTP(w, r)
 				return
@@ -101,20 +152,20 @@ func LogHandler(h http.Handler, opts *LogOptions) http.Handler {
 			// Save the response start time
 			st := time.Now()
 			// Call the wrapped handler, with the augmented ResponseWriter to handle the status code
-			stw := &statusResponseWriter{w, 0}
+			stw, sc := wrapStatusWriter(w)
 
 			// Log immediately if requested, otherwise on exit
 			if opts.Immediate {
-				logRequest(stw, r, st, opts)
+				logRequest(stw, sc, r, st, opts)
 			} else {
-				defer logRequest(stw, r, st, opts)
+				defer logRequest(stw, sc, r, st, opts)
 			}
 			h.ServeHTTP(stw, r)
 		})
 }
 
 // Check if the specified token is a predefined one, and if so return its current value.
-func getPredefinedTokenValue(t string, w *statusResponseWriter, r *http.Request,
+func getPredefinedTokenValue(t string, w http.ResponseWriter, sc *statusCapture, r *http.Request,
 	st time.Time, opts *LogOptions) (interface{}, bool) {
 
 	switch t {
@@ -135,7 +186,26 @@ func getPredefinedTokenValue(t string, w *statusResponseWriter, r *http.Request,
 	case "user-agent":
 		return r.UserAgent(), true
 	case "status":
-		return w.code, true
+		return sc.code, true
+	case "bytes-sent":
+		return sc.bytes, true
+	case "clf-date":
+		return time.Now().Format(clfDateFormat), true
+	case "forwarded-for":
+		if info, ok := r.Context().Value(proxyInfoKey{}).(*proxyInfo); ok {
+			return info.originalRemoteAddr, true
+		}
+		return r.RemoteAddr, true
+	case "forwarded-proto":
+		if info, ok := r.Context().Value(proxyInfoKey{}).(*proxyInfo); ok {
+			return info.claimedProto, true
+		}
+		return "", true
+	case "forwarded-host":
+		if info, ok := r.Context().Value(proxyInfoKey{}).(*proxyInfo); ok {
+			return info.claimedHost, true
+		}
+		return "", true
 	}
 
 	// Handle special cases for header
@@ -153,7 +223,7 @@ func getPredefinedTokenValue(t string, w *statusResponseWriter, r *http.Request,
 }
 
 // Do the actual logging.
-func logRequest(w *statusResponseWriter, r *http.Request, st time.Time, opts *LogOptions) {
+func logRequest(w http.ResponseWriter, sc *statusCapture, r *http.Request, st time.Time, opts *LogOptions) {
 	var (
 		fn     func(string, ...interface{})
 		ok     bool
@@ -176,9 +246,15 @@ func logRequest(w *statusResponseWriter, r *http.Request, st time.Time, opts *Lo
 		format = opts.Format
 		toks = opts.Tokens
 	}
+
+	if opts.Structured {
+		logStructured(fn, toks, w, sc, r, st, opts)
+		return
+	}
+
 	args := make([]interface{}, len(toks))
 	for i, t := range toks {
-		if args[i], ok = getPredefinedTokenValue(t, w, r, st, opts); !ok {
+		if args[i], ok = getPredefinedTokenValue(t, w, sc, r, st, opts); !ok {
 			if f, ok := opts.CustomTokens[t]; ok && f != nil {
 				args[i] = f(w, r)
 			} else {
@@ -189,14 +265,59 @@ func logRequest(w *statusResponseWriter, r *http.Request, st time.Time, opts *Lo
 	fn(format, args...)
 }
 
-// Helper function to retrieve the status writer.
-func getStatusWriter(w http.ResponseWriter) (*statusResponseWriter, bool) {
-	st, ok := GetResponseWriter(w, func(tst http.ResponseWriter) bool {
-		_, ok := tst.(*statusResponseWriter)
+// logStructured emits one JSON object, keyed by token name, instead of a
+// printf-formatted line. Predefined numeric tokens (status, response-time,
+// bytes-sent, and any *Content-Length header token whose value parses as an
+// integer) are encoded as JSON numbers; other resolved tokens, including
+// CustomTokens results, as strings; tokens that resolve to nothing as null.
+func logStructured(fn func(string, ...interface{}), toks []string, w http.ResponseWriter, sc *statusCapture, r *http.Request, st time.Time, opts *LogOptions) {
+	entry := make(map[string]interface{}, len(toks))
+	for _, t := range toks {
+		if v, ok := getPredefinedTokenValue(t, w, sc, r, st, opts); ok {
+			entry[t] = structuredTokenValue(t, v)
+			continue
+		}
+		if f, ok := opts.CustomTokens[t]; ok && f != nil {
+			entry[t] = f(w, r)
+			continue
+		}
+		entry[t] = nil
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		fn("%v", err)
+		return
+	}
+	fn("%s", data)
+}
+
+// structuredTokenValue converts a Content-Length header token's string
+// value to a JSON number; every other token's value from
+// getPredefinedTokenValue is already typed correctly (status is an int,
+// response-time a float64, bytes-sent an int64, everything else a string).
+func structuredTokenValue(t string, v interface{}) interface{} {
+	if s, ok := v.(string); ok && strings.Contains(strings.ToLower(t), "content-length") {
+		if n, err := strconv.Atoi(s); err == nil {
+			return n
+		}
+	}
+	return v
+}
+
+// getStatusCapture finds the statusCapture tagged onto w (or a writer it
+// wraps) by a previous call to wrapStatusWriter.
+func getStatusCapture(w http.ResponseWriter) (*statusCapture, bool) {
+	tw, ok := GetResponseWriter(w, func(tst http.ResponseWriter) bool {
+		tg, ok := tst.(tagger)
+		if !ok {
+			return false
+		}
+		_, ok = tg.Tag().(*statusCapture)
 		return ok
 	})
-	if ok {
-		return st.(*statusResponseWriter), true
+	if !ok {
+		return nil, false
 	}
-	return nil, false
-}
\ No newline at end of file
+	sc, _ := tw.(tagger).Tag().(*statusCapture)
+	return sc, true
+}
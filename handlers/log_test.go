@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// lineCapturingWriter records each Write call (minus its trailing newline)
+// as a separate entry, so tests can assert on individual log.Printf calls.
+type lineCapturingWriter struct{ lines *[]string }
+
+func (w lineCapturingWriter) Write(p []byte) (int, error) {
+	*w.lines = append(*w.lines, strings.TrimRight(string(p), "\n"))
+	return len(p), nil
+}
+
+func newLineCapturingLogger(lines *[]string) *log.Logger {
+	return log.New(lineCapturingWriter{lines}, "", 0)
+}
+
+func TestLogHandlerStructuredOutput(t *testing.T) {
+	var lines []string
+	opts := NewLogOptions(nil, "", "status", "method", "greeting")
+	opts.Structured = true
+	opts.Immediate = false
+	opts.CustomTokens["greeting"] = func(w http.ResponseWriter, r *http.Request) string { return "hi" }
+
+	h := LogHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}), opts)
+	capturePrintf(t, opts, &lines, func() {
+		r := httptest.NewRequest("POST", "/", nil)
+		h.ServeHTTP(httptest.NewRecorder(), r)
+	})
+
+	if len(lines) != 1 {
+		t.Fatalf("got %d log lines, want 1", len(lines))
+	}
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("log line isn't valid JSON: %v (%q)", err, lines[0])
+	}
+	if entry["status"] != float64(http.StatusCreated) {
+		t.Errorf("status = %v, want %d", entry["status"], http.StatusCreated)
+	}
+	if entry["method"] != "POST" {
+		t.Errorf("method = %v, want POST", entry["method"])
+	}
+	if entry["greeting"] != "hi" {
+		t.Errorf("greeting = %v, want hi", entry["greeting"])
+	}
+}
+
+func TestLogHandlerCombinedFormatUsesCLFDate(t *testing.T) {
+	var lines []string
+	opts := NewLogOptions(nil, Lcombined)
+
+	h := LogHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello"))
+	}), opts)
+	capturePrintf(t, opts, &lines, func() {
+		r := httptest.NewRequest("GET", "/", nil)
+		h.ServeHTTP(httptest.NewRecorder(), r)
+	})
+
+	if len(lines) != 1 {
+		t.Fatalf("got %d log lines, want 1", len(lines))
+	}
+	// clf-date renders like "30/Jul/2026:00:00:00 +0000"; the bracketed date
+	// must parse with the CLF layout, not time.RFC3339.
+	if !strings.Contains(lines[0], "[") || !strings.Contains(lines[0], "] \"GET / HTTP/1.1\" 200 5") {
+		t.Errorf("combined log line = %q, want an Apache-combined-shaped line", lines[0])
+	}
+	start, end := strings.Index(lines[0], "["), strings.Index(lines[0], "]")
+	if start == -1 || end < start {
+		t.Fatalf("combined log line = %q, want a bracketed date", lines[0])
+	}
+	if _, err := time.Parse(clfDateFormat, lines[0][start+1:end]); err != nil {
+		t.Errorf("date %q is not in CLF layout: %v", lines[0][start+1:end], err)
+	}
+}
+
+// capturePrintf redirects opts.Logger's output into *lines for the duration
+// of fn.
+func capturePrintf(t *testing.T, opts *LogOptions, lines *[]string, fn func()) {
+	t.Helper()
+	orig := opts.Logger
+	defer func() { opts.Logger = orig }()
+
+	opts.Logger = newLineCapturingLogger(lines)
+	fn()
+}
@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// Names of the headers ProxyHeaders understands. Use these with
+// ProxyHeadersOptions.Headers to restrict which ones are honored.
+const (
+	HeaderForwardedFor   = "X-Forwarded-For"
+	HeaderRealIP         = "X-Real-IP"
+	HeaderForwarded      = "Forwarded"
+	HeaderForwardedProto = "X-Forwarded-Proto"
+	HeaderForwardedHost  = "X-Forwarded-Host"
+)
+
+// ProxyHeadersOptions configures ProxyHeaders.
+type ProxyHeadersOptions struct {
+	// TrustedProxies lists the networks a direct TCP peer must belong to
+	// for its forwarding headers to be honored. Defaults to none, so
+	// ProxyHeaders is a no-op - and cannot be spoofed by an untrusted
+	// client - until explicitly configured.
+	TrustedProxies []*net.IPNet
+
+	// Headers restricts which of the Header* constants above are honored.
+	// A nil or empty slice honors all of them.
+	Headers []string
+}
+
+// NewProxyHeadersOptions returns an empty *ProxyHeadersOptions; ProxyHeaders
+// built with it is a no-op until TrustedProxies is populated.
+func NewProxyHeadersOptions() *ProxyHeadersOptions {
+	return &ProxyHeadersOptions{}
+}
+
+// proxyInfoKey is the context key ProxyHeaders stores request provenance
+// under, for the forwarded-for/-proto/-host log tokens to pick up.
+type proxyInfoKey struct{}
+
+// proxyInfo records what the request looked like before ProxyHeaders
+// rewrote it, and what the (possibly untrusted) client claimed.
+type proxyInfo struct {
+	originalRemoteAddr string
+	claimedProto       string
+	claimedHost        string
+}
+
+// ProxyHeaders overwrites r.RemoteAddr, r.URL.Scheme and r.Host from the
+// X-Forwarded-For/X-Real-IP/Forwarded and X-Forwarded-Proto/X-Forwarded-Host
+// headers, but only for requests whose direct TCP peer is in
+// opts.TrustedProxies - otherwise the request passes through unmodified,
+// since honoring these headers from an arbitrary client would let it spoof
+// its address. A nil opts is equivalent to NewProxyHeadersOptions(), i.e. a
+// no-op. Pair with the LogHandler forwarded-for/forwarded-proto/
+// forwarded-host tokens to log both the direct peer and the claimed client.
+func ProxyHeaders(h http.Handler, opts *ProxyHeadersOptions) http.Handler {
+	if opts == nil {
+		opts = NewProxyHeadersOptions()
+	}
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			info := &proxyInfo{
+				originalRemoteAddr: r.RemoteAddr,
+				claimedProto:       r.Header.Get(HeaderForwardedProto),
+				claimedHost:        r.Header.Get(HeaderForwardedHost),
+			}
+			r = r.WithContext(context.WithValue(r.Context(), proxyInfoKey{}, info))
+
+			if isTrustedProxy(r, opts) {
+				if ip, ok := claimedClientIP(r, opts); ok {
+					r.RemoteAddr = ip
+				}
+				if opts.allows(HeaderForwardedProto) && info.claimedProto != "" {
+					r.URL.Scheme = info.claimedProto
+				}
+				if opts.allows(HeaderForwardedHost) && info.claimedHost != "" {
+					r.Host = info.claimedHost
+				}
+			}
+			h.ServeHTTP(w, r)
+		})
+}
+
+// allows reports whether name is in opts.Headers, or opts.Headers is empty
+// (meaning every header is allowed).
+func (opts *ProxyHeadersOptions) allows(name string) bool {
+	if len(opts.Headers) == 0 {
+		return true
+	}
+	for _, h := range opts.Headers {
+		if strings.EqualFold(h, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTrustedProxy reports whether r's direct TCP peer is in opts.TrustedProxies.
+func isTrustedProxy(r *http.Request, opts *ProxyHeadersOptions) bool {
+	if len(opts.TrustedProxies) == 0 {
+		return false
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, n := range opts.TrustedProxies {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// claimedClientIP derives the client address a trusted proxy is claiming,
+// preferring X-Forwarded-For (leftmost non-private hop), then X-Real-IP,
+// then the RFC 7239 Forwarded header.
+func claimedClientIP(r *http.Request, opts *ProxyHeadersOptions) (string, bool) {
+	if opts.allows(HeaderForwardedFor) {
+		if xff := r.Header.Get(HeaderForwardedFor); xff != "" {
+			if ip, ok := leftmostNonPrivate(xff); ok {
+				return ip, true
+			}
+		}
+	}
+	if opts.allows(HeaderRealIP) {
+		if ip := r.Header.Get(HeaderRealIP); ip != "" && net.ParseIP(ip) != nil {
+			return ip, true
+		}
+	}
+	if opts.allows(HeaderForwarded) {
+		if fwd := r.Header.Get(HeaderForwarded); fwd != "" {
+			if ip, ok := parseForwardedFor(fwd); ok {
+				return ip, true
+			}
+		}
+	}
+	return "", false
+}
+
+// leftmostNonPrivate returns the first address in a comma-separated
+// X-Forwarded-For list that isn't a private, loopback or link-local
+// address, falling back to the leftmost address if every hop is private.
+func leftmostNonPrivate(xff string) (string, bool) {
+	var leftmost net.IP
+	for _, part := range strings.Split(xff, ",") {
+		ip := net.ParseIP(strings.TrimSpace(part))
+		if ip == nil {
+			continue
+		}
+		if leftmost == nil {
+			leftmost = ip
+		}
+		if !isPrivateOrLocal(ip) {
+			return ip.String(), true
+		}
+	}
+	if leftmost != nil {
+		return leftmost.String(), true
+	}
+	return "", false
+}
+
+func isPrivateOrLocal(ip net.IP) bool {
+	return ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified()
+}
+
+// parseForwardedFor extracts the "for" parameter of the first element of an
+// RFC 7239 Forwarded header value, stripping the optional port and the
+// brackets/quotes the RFC allows around it.
+func parseForwardedFor(v string) (string, bool) {
+	first := strings.Split(v, ",")[0]
+	for _, kv := range strings.Split(first, ";") {
+		k, val, found := strings.Cut(strings.TrimSpace(kv), "=")
+		if !found || !strings.EqualFold(strings.TrimSpace(k), "for") {
+			continue
+		}
+		val = strings.Trim(strings.TrimSpace(val), `"`)
+		val = strings.TrimPrefix(val, "[")
+		if idx := strings.Index(val, "]"); idx != -1 {
+			val = val[:idx]
+		} else if host, _, err := net.SplitHostPort(val); err == nil {
+			val = host
+		}
+		if val == "" {
+			return "", false
+		}
+		return val, true
+	}
+	return "", false
+}
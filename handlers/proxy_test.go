@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func trustedOpts(cidr string) *ProxyHeadersOptions {
+	_, n, _ := net.ParseCIDR(cidr)
+	return &ProxyHeadersOptions{TrustedProxies: []*net.IPNet{n}}
+}
+
+func TestProxyHeadersNoOpWithoutTrustedProxies(t *testing.T) {
+	h := ProxyHeaders(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.RemoteAddr != "203.0.113.5:1234" {
+			t.Errorf("RemoteAddr = %q, want unchanged", r.RemoteAddr)
+		}
+	}), nil)
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = "203.0.113.5:1234"
+	r.Header.Set("X-Forwarded-For", "198.51.100.9")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+}
+
+func TestProxyHeadersLeftmostNonPrivateHop(t *testing.T) {
+	opts := trustedOpts("203.0.113.5/32")
+	h := ProxyHeaders(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.RemoteAddr != "198.51.100.9" {
+			t.Errorf("RemoteAddr = %q, want %q", r.RemoteAddr, "198.51.100.9")
+		}
+	}), opts)
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = "203.0.113.5:1234"
+	r.Header.Set("X-Forwarded-For", "10.0.0.1, 198.51.100.9, 192.168.1.1")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+}
+
+func TestProxyHeadersSchemeAndHost(t *testing.T) {
+	opts := trustedOpts("203.0.113.5/32")
+	h := ProxyHeaders(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Scheme != "https" {
+			t.Errorf("Scheme = %q, want https", r.URL.Scheme)
+		}
+		if r.Host != "example.com" {
+			t.Errorf("Host = %q, want example.com", r.Host)
+		}
+	}), opts)
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = "203.0.113.5:1234"
+	r.Header.Set("X-Forwarded-Proto", "https")
+	r.Header.Set("X-Forwarded-Host", "example.com")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+}
+
+func TestProxyHeadersLogTokens(t *testing.T) {
+	opts := trustedOpts("203.0.113.5/32")
+
+	var forwardedFor, forwardedProto, forwardedHost, remoteAddr string
+	logOpts := NewLogOptions(nil, "%s")
+	logOpts.Tokens = []string{"forwarded-for"}
+	logOpts.CustomTokens["capture"] = func(w http.ResponseWriter, r *http.Request) string {
+		v, _ := getPredefinedTokenValue("forwarded-for", w, &statusCapture{}, r, time.Now(), logOpts)
+		forwardedFor, _ = v.(string)
+		v, _ = getPredefinedTokenValue("forwarded-proto", w, &statusCapture{}, r, time.Now(), logOpts)
+		forwardedProto, _ = v.(string)
+		v, _ = getPredefinedTokenValue("forwarded-host", w, &statusCapture{}, r, time.Now(), logOpts)
+		forwardedHost, _ = v.(string)
+		v, _ = getPredefinedTokenValue("remote-addr", w, &statusCapture{}, r, time.Now(), logOpts)
+		remoteAddr, _ = v.(string)
+		return ""
+	}
+	logOpts.Tokens = []string{"capture"}
+	logOpts.Immediate = true
+
+	h := ProxyHeaders(LogHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}), logOpts), opts)
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = "203.0.113.5:1234"
+	r.Header.Set("X-Forwarded-For", "198.51.100.9")
+	r.Header.Set("X-Forwarded-Proto", "https")
+	r.Header.Set("X-Forwarded-Host", "example.com")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+
+	if forwardedFor != "203.0.113.5:1234" {
+		t.Errorf("forwarded-for = %q, want original peer %q", forwardedFor, "203.0.113.5:1234")
+	}
+	if forwardedProto != "https" {
+		t.Errorf("forwarded-proto = %q, want %q", forwardedProto, "https")
+	}
+	if forwardedHost != "example.com" {
+		t.Errorf("forwarded-host = %q, want %q", forwardedHost, "example.com")
+	}
+	if remoteAddr != "198.51.100.9" {
+		t.Errorf("remote-addr = %q, want the claimed client %q", remoteAddr, "198.51.100.9")
+	}
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"runtime"
+
+	"github.com/PuerkitoBio/ghost/handlers/rwwrap"
+)
+
+// RecoveryOptions configures RecoveryHandler.
+type RecoveryOptions struct {
+	// Logger receives the panic log line. Falls back to the standard log
+	// package, like LogOptions.Logger does.
+	Logger *log.Logger
+
+	// PrintStack, when true, includes the captured stack trace in the log
+	// line.
+	PrintStack bool
+
+	// StackSize bounds how many bytes of stack trace are captured. Defaults
+	// to 8KB.
+	StackSize int
+
+	// PanicHandler, if set, is called instead of writing the default 500
+	// response, so applications can render a custom error page or emit a
+	// structured event. It is responsible for writing the response.
+	PanicHandler func(w http.ResponseWriter, r *http.Request, err interface{}, stack []byte)
+}
+
+// NewRecoveryOptions returns a *RecoveryOptions with the default StackSize.
+func NewRecoveryOptions() *RecoveryOptions {
+	return &RecoveryOptions{StackSize: 8 * 1024}
+}
+
+// RecoveryHandler recovers from panics raised by h, logs the panic value
+// and a filtered stack trace, and writes a 500 response if h had not
+// already sent one. Like the standard library, it re-panics on
+// http.ErrAbortHandler instead of handling it. A nil opts is equivalent to
+// NewRecoveryOptions().
+func RecoveryHandler(h http.Handler, opts *RecoveryOptions) http.Handler {
+	if opts == nil {
+		opts = NewRecoveryOptions()
+	}
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			written := false
+			rw := rwwrap.Wrap(w, rwwrap.Hooks{
+				WriteHeader: func(next rwwrap.WriteHeaderFunc) rwwrap.WriteHeaderFunc {
+					return func(code int) {
+						written = true
+						next(code)
+					}
+				},
+				Write: func(next rwwrap.WriteFunc) rwwrap.WriteFunc {
+					return func(data []byte) (int, error) {
+						written = true
+						return next(data)
+					}
+				},
+			})
+
+			defer func() {
+				err := recover()
+				if err == nil {
+					return
+				}
+				if err == http.ErrAbortHandler {
+					// Preserve the standard-library convention: this value
+					// means "silently stop", not "log and recover".
+					panic(err)
+				}
+
+				stack := capturedStack(opts.stackSize())
+				logPanic(opts, err, stack)
+
+				if opts.PanicHandler != nil {
+					opts.PanicHandler(rw, r, err, stack)
+					return
+				}
+				if !written {
+					rw.WriteHeader(http.StatusInternalServerError)
+				}
+			}()
+			h.ServeHTTP(rw, r)
+		})
+}
+
+func (o *RecoveryOptions) stackSize() int {
+	if o.StackSize > 0 {
+		return o.StackSize
+	}
+	return 8 * 1024
+}
+
+// logPanic logs the panic value, and the stack trace if opts.PrintStack is
+// set, through opts.Logger (or the standard log package if nil).
+func logPanic(opts *RecoveryOptions, err interface{}, stack []byte) {
+	fn := log.Printf
+	if opts.Logger != nil {
+		fn = opts.Logger.Printf
+	}
+	if opts.PrintStack {
+		fn("panic: %v\n%s", err, stack)
+	} else {
+		fn("panic: %v", err)
+	}
+}
+
+// capturedStack returns up to size bytes of the current goroutine's stack,
+// with the leading frames (capturedStack itself, the deferred recover func
+// and the panic call) skipped so the trace starts at the code that
+// panicked.
+func capturedStack(size int) []byte {
+	buf := make([]byte, size)
+	n := runtime.Stack(buf, false)
+	return skipRuntimeFrames(buf[:n])
+}
+
+// skipRuntimeFrames drops the leading "function\n\tfile:line" frame pairs up
+// to and including the panic frame, then any runtime frames right after it
+// (such as runtime.sigpanic), leaving the header line ("goroutine N
+// [running]:") followed directly by application frames. If there is no
+// panic frame, only leading runtime frames are dropped.
+func skipRuntimeFrames(stack []byte) []byte {
+	lines := bytes.Split(stack, []byte("\n"))
+	if len(lines) < 2 {
+		return stack
+	}
+	i := 1
+	for j := 1; j+1 < len(lines); j += 2 {
+		if bytes.HasPrefix(lines[j], []byte("panic(")) || bytes.HasPrefix(lines[j], []byte("runtime.gopanic(")) {
+			i = j + 2
+			break
+		}
+	}
+	for i+1 < len(lines) {
+		if !bytes.HasPrefix(lines[i], []byte("runtime.")) {
+			break
+		}
+		i += 2 // function name line, then its "\tfile:line" line
+	}
+	out := append(lines[:1:1], lines[i:]...)
+	return bytes.Join(out, []byte("\n"))
+}
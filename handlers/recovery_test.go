@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRecoveryHandlerWrites500OnPanic(t *testing.T) {
+	h := RecoveryHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}), nil)
+
+	r := httptest.NewRequest("GET", "/", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+}
+
+func TestRecoveryHandlerDoesNotOverwriteSentHeaders(t *testing.T) {
+	h := RecoveryHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		panic("boom after headers sent")
+	}), nil)
+
+	r := httptest.NewRequest("GET", "/", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if w.Code != http.StatusAccepted {
+		t.Errorf("status = %d, want %d (should not be overwritten by recovery)", w.Code, http.StatusAccepted)
+	}
+}
+
+func TestRecoveryHandlerPanicHandler(t *testing.T) {
+	var gotErr interface{}
+	opts := NewRecoveryOptions()
+	opts.PanicHandler = func(w http.ResponseWriter, r *http.Request, err interface{}, stack []byte) {
+		gotErr = err
+		w.WriteHeader(http.StatusTeapot)
+	}
+	h := RecoveryHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("custom")
+	}), opts)
+
+	r := httptest.NewRequest("GET", "/", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if w.Code != http.StatusTeapot {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusTeapot)
+	}
+	if gotErr != "custom" {
+		t.Errorf("PanicHandler err = %v, want %q", gotErr, "custom")
+	}
+}
+
+func TestRecoveryHandlerRepanicsOnErrAbortHandler(t *testing.T) {
+	h := RecoveryHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}), nil)
+
+	defer func() {
+		if recover() != http.ErrAbortHandler {
+			t.Error("expected http.ErrAbortHandler to propagate past RecoveryHandler")
+		}
+	}()
+
+	r := httptest.NewRequest("GET", "/", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	t.Fatal("ServeHTTP should not have returned normally")
+}
+
+func TestRecoveryHandlerCooperatesWithLogHandler(t *testing.T) {
+	var loggedStatus interface{}
+	logOpts := NewLogOptions(nil, "_tiny_")
+	logOpts.CustomTokens["test-status"] = func(w http.ResponseWriter, r *http.Request) string {
+		sc, _ := getStatusCapture(w)
+		if sc != nil {
+			loggedStatus = sc.code
+		}
+		return ""
+	}
+	logOpts.Tokens = []string{"test-status"}
+	logOpts.Format = "%s"
+
+	h := LogHandler(RecoveryHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}), nil), logOpts)
+
+	r := httptest.NewRequest("GET", "/", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+	if loggedStatus != http.StatusInternalServerError {
+		t.Errorf("LogHandler's captured status = %v, want %d", loggedStatus, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import "net/http"
+
+// tagger is implemented by writers returned from rwwrap.Wrap that were
+// created with a non-nil Hooks.Tag. It lets middleware further down the
+// chain recognize a specific wrapper without knowing its concrete
+// (generated) type.
+type tagger interface {
+	Tag() interface{}
+}
+
+// unwrapper is implemented by writers returned from rwwrap.Wrap, following
+// the same Unwrap() http.ResponseWriter convention used by the standard
+// library's http.ResponseController.
+type unwrapper interface {
+	Unwrap() http.ResponseWriter
+}
+
+// GetResponseWriter walks the chain of wrapped http.ResponseWriters starting
+// at w, calling match on each one in turn, and returns the first writer for
+// which match returns true. The chain is followed through any wrapper that
+// implements Unwrap() http.ResponseWriter.
+func GetResponseWriter(w http.ResponseWriter, match func(http.ResponseWriter) bool) (http.ResponseWriter, bool) {
+	for {
+		if match(w) {
+			return w, true
+		}
+		uw, ok := w.(unwrapper)
+		if !ok {
+			return nil, false
+		}
+		w = uw.Unwrap()
+	}
+}
@@ -0,0 +1,154 @@
+// Command gen emits combinations_gen.go: one concrete type per subset of
+// {CloseNotifier, Flusher, Hijacker, Pusher, ReadFrom}, plus the pick()
+// dispatcher that Wrap uses to pick the type matching a given writer.
+//
+// Run via `go generate` from the rwwrap package (see the directive in
+// hooks.go); it is not meant to be invoked directly.
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+	"text/template"
+)
+
+type iface struct {
+	Name     string // e.g. "Flusher", used in type names
+	CondName string // e.g. "Flusher", matches the isXxx pick() parameter
+	Type     string // e.g. "http.Flusher"
+	Field    string // embedded func field name, e.g. "fl"
+	FuncType string // e.g. "FlushFunc"
+	Maker    string // e.g. "makeFlush"
+}
+
+var ifaces = []iface{
+	{"CloseNotifier", "CloseNotifier", "http.CloseNotifier", "cn", "CloseNotifyFunc", "makeCloseNotify"},
+	{"Flusher", "Flusher", "http.Flusher", "fl", "FlushFunc", "makeFlush"},
+	{"Hijacker", "Hijacker", "http.Hijacker", "hj", "HijackFunc", "makeHijack"},
+	{"Pusher", "Pusher", "http.Pusher", "ph", "PushFunc", "makePush"},
+	{"ReadFrom", "ReaderFrom", "io.ReaderFrom", "rf", "ReadFromFunc", "makeReadFrom"},
+}
+
+const tmplSrc = `// Code generated by go generate; DO NOT EDIT.
+
+package rwwrap
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+)
+
+{{range .Combos}}
+// {{.TypeName}} implements http.ResponseWriter plus {{.Comment}}.
+type {{.TypeName}} struct {
+	rwBase
+{{range .Ifaces}}	{{.Field}} {{.FuncType}}
+{{end}}}
+{{end}}
+
+// pick selects the generated type matching the given optional-interface
+// support and binds its hooks.
+func pick(w http.ResponseWriter, hooks Hooks, isCloseNotifier, isFlusher, isHijacker, isPusher, isReaderFrom bool) http.ResponseWriter {
+	switch {
+{{range .Combos}}	case {{.Cond}}:
+		return &{{.TypeName}}{
+			rwBase: rwBase{w: w, wh: makeWriteHeader(w, hooks), wr: makeWrite(w, hooks), tag: hooks.Tag},
+{{range .Ifaces}}			{{.Field}}: {{.Maker}}(w.({{.Type}}), hooks),
+{{end}}		}
+{{end}}	}
+	panic("rwwrap: unreachable combination")
+}
+`
+
+type combo struct {
+	Ifaces   []iface
+	TypeName string
+	Comment  string
+	Cond     string
+}
+
+func main() {
+	var combos []combo
+	for mask := 0; mask < (1 << len(ifaces)); mask++ {
+		var (
+			present []iface
+			names   []string
+			conds   []string
+		)
+		for i, iface := range ifaces {
+			bit := mask&(1<<uint(i)) != 0
+			if bit {
+				present = append(present, iface)
+				names = append(names, iface.Name)
+			}
+			if bit {
+				conds = append(conds, "is"+iface.CondName)
+			} else {
+				conds = append(conds, "!is"+iface.CondName)
+			}
+		}
+		typeName := "rw"
+		if len(names) > 0 {
+			typeName = "rw" + strings.Join(names, "")
+		}
+		comment := "no optional interfaces"
+		if len(names) > 0 {
+			comment = strings.Join(names, ", ")
+		}
+		combos = append(combos, combo{
+			Ifaces:   present,
+			TypeName: typeName,
+			Comment:  comment,
+			Cond:     strings.Join(conds, " && "),
+		})
+	}
+
+	tmpl := template.Must(template.New("gen").Parse(tmplSrc))
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, struct{ Combos []combo }{combos}); err != nil {
+		log.Fatal(err)
+	}
+
+	// Append the per-interface method bodies (the template above only
+	// declares the struct fields; methods are easier to emit as flat text).
+	var methods bytes.Buffer
+	for _, c := range combos {
+		for _, f := range c.Ifaces {
+			switch f.Name {
+			case "CloseNotifier":
+				methods.WriteString("func (c *" + c.TypeName + ") CloseNotify() <-chan bool { return c.cn() }\n")
+			case "Flusher":
+				methods.WriteString("func (c *" + c.TypeName + ") Flush() { c.fl() }\n")
+			case "Hijacker":
+				methods.WriteString("func (c *" + c.TypeName + ") Hijack() (net.Conn, *bufio.ReadWriter, error) { return c.hj() }\n")
+			case "Pusher":
+				methods.WriteString("func (c *" + c.TypeName + ") Push(target string, opts *http.PushOptions) error { return c.ph(target, opts) }\n")
+			case "ReadFrom":
+				methods.WriteString("func (c *" + c.TypeName + ") ReadFrom(src io.Reader) (int64, error) { return c.rf(src) }\n")
+			}
+		}
+	}
+
+	out := strings.Replace(buf.String(), "\n\n\n", "\n\n", -1) + "\n" + methods.String()
+
+	// go generate runs this command with its working directory set to the
+	// package containing the //go:generate directive (rwwrap), not this gen
+	// package, so the output path is relative to rwwrap, not to gen.
+	const outPath = "combinations_gen.go"
+
+	formatted, err := format.Source([]byte(out))
+	if err != nil {
+		// Write the unformatted source too, to ease debugging a template bug.
+		os.WriteFile(outPath, []byte(out), 0644)
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(outPath, formatted, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,143 @@
+// Package rwwrap provides an httpsnoop-style http.ResponseWriter wrapper
+// that preserves whichever optional interfaces (http.Hijacker, http.Flusher,
+// http.Pusher, http.CloseNotifier, io.ReaderFrom) the wrapped writer happens
+// to implement. Middleware that needs to observe or alter writes - logging,
+// compression, recovery - can use Wrap instead of hand-rolling a wrapper
+// type that silently drops those interfaces and breaks websocket upgrades,
+// SSE, and HTTP/2 push.
+package rwwrap
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+)
+
+//go:generate go run ./gen
+
+type (
+	WriteHeaderFunc func(code int)
+	WriteFunc       func(b []byte) (int, error)
+	FlushFunc       func()
+	HijackFunc      func() (net.Conn, *bufio.ReadWriter, error)
+	ReadFromFunc    func(src io.Reader) (int64, error)
+	PushFunc        func(target string, opts *http.PushOptions) error
+	CloseNotifyFunc func() <-chan bool
+)
+
+// Hooks let a caller wrap the methods of the underlying http.ResponseWriter
+// (and any optional interfaces it implements). Each field receives the
+// "next" func to call and returns the func that should run in its place; a
+// nil field leaves the corresponding method untouched.
+type Hooks struct {
+	WriteHeader func(WriteHeaderFunc) WriteHeaderFunc
+	Write       func(WriteFunc) WriteFunc
+	Flush       func(FlushFunc) FlushFunc
+	Hijack      func(HijackFunc) HijackFunc
+	ReadFrom    func(ReadFromFunc) ReadFromFunc
+	Push        func(PushFunc) PushFunc
+	CloseNotify func(CloseNotifyFunc) CloseNotifyFunc
+
+	// Tag, when non-nil, is attached to the returned writer and retrievable
+	// via its Tag() method. It lets a piece of middleware recognize a
+	// writer it previously wrapped further down a handler chain (see
+	// handlers.GetResponseWriter) without needing to know which of the
+	// generated wrapper types Wrap picked.
+	Tag interface{}
+}
+
+// Unwrap is implemented by every writer returned from Wrap, so callers that
+// need to walk a chain of wrappers (see handlers.GetResponseWriter) can reach the
+// original http.ResponseWriter.
+type Unwrapper interface {
+	Unwrap() http.ResponseWriter
+}
+
+// Wrap returns a http.ResponseWriter that implements exactly the same
+// subset of {http.Hijacker, http.Flusher, http.Pusher, http.CloseNotifier,
+// io.ReaderFrom} as w, with hooks applied to WriteHeader/Write and to any of
+// those optional methods that are present. The returned writer always
+// implements Unwrap() http.ResponseWriter.
+func Wrap(w http.ResponseWriter, hooks Hooks) http.ResponseWriter {
+	_, isCloseNotifier := w.(http.CloseNotifier)
+	_, isFlusher := w.(http.Flusher)
+	_, isHijacker := w.(http.Hijacker)
+	_, isPusher := w.(http.Pusher)
+	_, isReaderFrom := w.(io.ReaderFrom)
+
+	return pick(w, hooks, isCloseNotifier, isFlusher, isHijacker, isPusher, isReaderFrom)
+}
+
+func makeWriteHeader(w http.ResponseWriter, hooks Hooks) WriteHeaderFunc {
+	f := w.WriteHeader
+	if hooks.WriteHeader != nil {
+		f = hooks.WriteHeader(f)
+	}
+	return f
+}
+
+func makeWrite(w http.ResponseWriter, hooks Hooks) WriteFunc {
+	f := w.Write
+	if hooks.Write != nil {
+		f = hooks.Write(f)
+	}
+	return f
+}
+
+func makeFlush(w http.Flusher, hooks Hooks) FlushFunc {
+	f := w.Flush
+	if hooks.Flush != nil {
+		f = hooks.Flush(f)
+	}
+	return f
+}
+
+func makeHijack(w http.Hijacker, hooks Hooks) HijackFunc {
+	f := w.Hijack
+	if hooks.Hijack != nil {
+		f = hooks.Hijack(f)
+	}
+	return f
+}
+
+func makeReadFrom(w io.ReaderFrom, hooks Hooks) ReadFromFunc {
+	f := w.ReadFrom
+	if hooks.ReadFrom != nil {
+		f = hooks.ReadFrom(f)
+	}
+	return f
+}
+
+func makePush(w http.Pusher, hooks Hooks) PushFunc {
+	f := w.Push
+	if hooks.Push != nil {
+		f = hooks.Push(f)
+	}
+	return f
+}
+
+func makeCloseNotify(w http.CloseNotifier, hooks Hooks) CloseNotifyFunc {
+	f := w.CloseNotify
+	if hooks.CloseNotify != nil {
+		f = hooks.CloseNotify(f)
+	}
+	return f
+}
+
+// rwBase is embedded by every generated combination type in
+// combinations_gen.go. It carries
+// the bound WriteHeader/Write hooks and the inner writer, and satisfies
+// http.ResponseWriter plus Unwrapper on its own.
+type rwBase struct {
+	w   http.ResponseWriter
+	wh  WriteHeaderFunc
+	wr  WriteFunc
+	tag interface{}
+}
+
+func (b *rwBase) Header() http.Header         { return b.w.Header() }
+func (b *rwBase) WriteHeader(code int)        { b.wh(code) }
+func (b *rwBase) Write(p []byte) (int, error) { return b.wr(p) }
+func (b *rwBase) Unwrap() http.ResponseWriter { return b.w }
+func (b *rwBase) Tag() interface{}            { return b.tag }
@@ -0,0 +1,156 @@
+package rwwrap
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// plainResponseWriter implements only http.ResponseWriter, none of the five
+// optional interfaces Wrap knows how to preserve.
+type plainResponseWriter struct {
+	header http.Header
+	code   int
+	body   []byte
+}
+
+func newPlainResponseWriter() *plainResponseWriter {
+	return &plainResponseWriter{header: make(http.Header)}
+}
+
+func (w *plainResponseWriter) Header() http.Header  { return w.header }
+func (w *plainResponseWriter) WriteHeader(code int) { w.code = code }
+func (w *plainResponseWriter) Write(p []byte) (int, error) {
+	w.body = append(w.body, p...)
+	return len(p), nil
+}
+
+// hijackableResponseWriter additionally implements http.Hijacker and
+// http.Flusher.
+type hijackableResponseWriter struct {
+	*plainResponseWriter
+	hijacked bool
+	flushed  bool
+}
+
+func (w *hijackableResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.hijacked = true
+	return nil, nil, nil
+}
+
+func (w *hijackableResponseWriter) Flush() { w.flushed = true }
+
+func TestWrapPreservesNoOptionalInterfaces(t *testing.T) {
+	inner := newPlainResponseWriter()
+	w := Wrap(inner, Hooks{})
+
+	if _, ok := w.(http.Hijacker); ok {
+		t.Errorf("wrapped writer implements http.Hijacker, want it not to")
+	}
+	if _, ok := w.(http.Flusher); ok {
+		t.Errorf("wrapped writer implements http.Flusher, want it not to")
+	}
+
+	w.WriteHeader(http.StatusTeapot)
+	w.Write([]byte("hi"))
+	if inner.code != http.StatusTeapot {
+		t.Errorf("code = %d, want %d", inner.code, http.StatusTeapot)
+	}
+	if string(inner.body) != "hi" {
+		t.Errorf("body = %q, want %q", inner.body, "hi")
+	}
+}
+
+func TestWrapPreservesHijackerAndFlusher(t *testing.T) {
+	inner := &hijackableResponseWriter{plainResponseWriter: newPlainResponseWriter()}
+	w := Wrap(inner, Hooks{})
+
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		t.Fatalf("wrapped writer does not implement http.Hijacker, want it to")
+	}
+	if _, _, err := hj.Hijack(); err != nil {
+		t.Errorf("Hijack() error = %v, want nil", err)
+	}
+	if !inner.hijacked {
+		t.Errorf("Hijack() on the wrapped writer did not reach the inner writer")
+	}
+
+	fl, ok := w.(http.Flusher)
+	if !ok {
+		t.Fatalf("wrapped writer does not implement http.Flusher, want it to")
+	}
+	fl.Flush()
+	if !inner.flushed {
+		t.Errorf("Flush() on the wrapped writer did not reach the inner writer")
+	}
+
+	if _, ok := w.(http.Pusher); ok {
+		t.Errorf("wrapped writer implements http.Pusher, want it not to")
+	}
+}
+
+func TestWrapHooksSeeWriteHeaderAndWrite(t *testing.T) {
+	inner := newPlainResponseWriter()
+	var gotCode int
+	var gotBytes int
+
+	w := Wrap(inner, Hooks{
+		WriteHeader: func(next WriteHeaderFunc) WriteHeaderFunc {
+			return func(code int) {
+				gotCode = code
+				next(code)
+			}
+		},
+		Write: func(next WriteFunc) WriteFunc {
+			return func(p []byte) (int, error) {
+				n, err := next(p)
+				gotBytes += n
+				return n, err
+			}
+		},
+	})
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("hello"))
+
+	if gotCode != http.StatusOK {
+		t.Errorf("hook saw code %d, want %d", gotCode, http.StatusOK)
+	}
+	if gotBytes != 5 {
+		t.Errorf("hook saw %d bytes, want 5", gotBytes)
+	}
+}
+
+func TestWrapWithHTTPTestRecorder(t *testing.T) {
+	// httptest.ResponseRecorder implements http.Flusher but none of the
+	// other four optional interfaces, exercising WriteHeader and Write
+	// through a real net/http type.
+	rec := httptest.NewRecorder()
+	w := Wrap(rec, Hooks{})
+
+	w.WriteHeader(http.StatusAccepted)
+	w.Write([]byte("ok"))
+
+	if rec.Code != http.StatusAccepted {
+		t.Errorf("code = %d, want %d", rec.Code, http.StatusAccepted)
+	}
+	if rec.Body.String() != "ok" {
+		t.Errorf("body = %q, want %q", rec.Body.String(), "ok")
+	}
+}
+
+func TestWrapUnwrap(t *testing.T) {
+	inner := newPlainResponseWriter()
+	w := Wrap(inner, Hooks{})
+
+	u, ok := w.(Unwrapper)
+	if !ok {
+		t.Fatalf("wrapped writer does not implement Unwrapper")
+	}
+	if u.Unwrap() != http.ResponseWriter(inner) {
+		t.Errorf("Unwrap() did not return the original writer")
+	}
+}